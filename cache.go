@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cacheEntry is the on-disk record for a single cached GET response, keyed by
+// request URL. Storing ETag/Last-Modified lets us reissue the request as a
+// conditional one; GitHub does not count a 304 response against the rate
+// limit, so a warm cache is effectively free.
+type cacheEntry struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	Body         []byte `json:"body"`
+}
+
+// diskCache stores cacheEntry values under a directory, one file per URL.
+type diskCache struct {
+	dir string
+}
+
+// newDiskCache returns a diskCache rooted at dir, creating it if necessary.
+// A zero-value diskCache (dir == "") is a valid no-op cache.
+func newDiskCache(dir string) (diskCache, error) {
+	if dir == "" {
+		return diskCache{}, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return diskCache{}, err
+	}
+	return diskCache{dir: dir}, nil
+}
+
+// path keys the cache file on both the URL and the credential used to fetch
+// it (e.g. the Authorization header), so switching -token/GITHUB_TOKEN to a
+// different account can never replay a cached response fetched under the
+// old identity.
+func (c diskCache) path(url, authorization string) string {
+	sum := sha256.Sum256([]byte(authorization + "\x00" + url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c diskCache) Get(url, authorization string) (cacheEntry, bool) {
+	if c.dir == "" {
+		return cacheEntry{}, false
+	}
+
+	data, err := os.ReadFile(c.path(url, authorization))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c diskCache) Put(url, authorization string, entry cacheEntry) error {
+	if c.dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(url, authorization), data, 0o600)
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestDiskCachePutGetRoundTrip(t *testing.T) {
+	cache, err := newDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDiskCache: %v", err)
+	}
+
+	entry := cacheEntry{ETag: `"abc"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT", Body: []byte("hello")}
+	if err := cache.Put("https://api.github.com/users/octocat/events", "token xyz", entry); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := cache.Get("https://api.github.com/users/octocat/events", "token xyz")
+	if !ok {
+		t.Fatal("expected cache hit after Put")
+	}
+	if got.ETag != entry.ETag || string(got.Body) != string(entry.Body) {
+		t.Errorf("got %+v, want %+v", got, entry)
+	}
+}
+
+func TestDiskCacheMissWhenAuthorizationDiffers(t *testing.T) {
+	cache, err := newDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDiskCache: %v", err)
+	}
+
+	url := "https://api.github.com/user"
+	if err := cache.Put(url, "token account-a", cacheEntry{Body: []byte("account a")}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if _, ok := cache.Get(url, "token account-b"); ok {
+		t.Fatal("expected a cache miss when the Authorization header changes, to avoid leaking one account's cached response to another")
+	}
+}
+
+func TestDiskCacheNoopWhenDirEmpty(t *testing.T) {
+	var cache diskCache
+	if err := cache.Put("https://example.com", "", cacheEntry{Body: []byte("x")}); err != nil {
+		t.Fatalf("Put on no-op cache should not error: %v", err)
+	}
+	if _, ok := cache.Get("https://example.com", ""); ok {
+		t.Fatal("expected a no-op cache to never report a hit")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EventFilter is an allow/deny list of GitHub event types (e.g.
+// "PushEvent"). Allow, if non-empty, is authoritative: only listed types
+// are processed. Otherwise Deny removes the listed types from an
+// otherwise-open set.
+type EventFilter struct {
+	Allow []string `json:"allow" yaml:"allow"`
+	Deny  []string `json:"deny" yaml:"deny"`
+}
+
+// IsEventProcessable reports whether eventType passes the filter.
+func (f EventFilter) IsEventProcessable(eventType string) bool {
+	if len(f.Allow) > 0 {
+		return containsFold(f.Allow, eventType)
+	}
+	return !containsFold(f.Deny, eventType)
+}
+
+// RepoFilter is an include/exclude list of glob patterns (e.g.
+// "myorg/*") matched against a repo's "owner/name".
+type RepoFilter struct {
+	Include []string `json:"include" yaml:"include"`
+	Exclude []string `json:"exclude" yaml:"exclude"`
+}
+
+// IsRepoProcessable reports whether repo passes the filter.
+func (f RepoFilter) IsRepoProcessable(repo string) bool {
+	if len(f.Include) > 0 && !matchesAny(f.Include, repo) {
+		return false
+	}
+	return !matchesAny(f.Exclude, repo)
+}
+
+// Config is the on-disk wdidt configuration, loaded via -config. Token,
+// Users, Org, and Format can be overridden by their corresponding CLI flags;
+// Events and Repos have no CLI flag and are set only here.
+type Config struct {
+	Token  string      `json:"token" yaml:"token"`
+	Users  []string    `json:"users" yaml:"users"`
+	Org    string      `json:"org" yaml:"org"`
+	Format string      `json:"format" yaml:"format"`
+	Events EventFilter `json:"events" yaml:"events"`
+	Repos  RepoFilter  `json:"repos" yaml:"repos"`
+}
+
+// loadConfig reads a Config from path, which may be empty (an empty Config
+// is returned). JSON and YAML are both supported; the format is chosen by
+// the file extension, defaulting to JSON.
+func loadConfig(path string) (Config, error) {
+	if path == "" {
+		return Config{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		err = yaml.Unmarshal(data, &cfg)
+	} else {
+		err = json.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// resolveToken picks the access token from, in order: the -token flag, the
+// config file, the GITHUB_TOKEN/GH_TOKEN env vars, and finally the gh CLI's
+// own stored credential. This keeps the token off the command line and out
+// of shell history in normal use.
+func resolveToken(flagToken, configToken string) string {
+	if flagToken != "" {
+		return flagToken
+	}
+	if configToken != "" {
+		return configToken
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	if token := os.Getenv("GH_TOKEN"); token != "" {
+		return token
+	}
+	if out, err := exec.Command("gh", "auth", "token").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	return ""
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if ok, err := filepath.Match(pattern, s); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestEventFilterAllowList(t *testing.T) {
+	f := EventFilter{Allow: []string{"PushEvent", "IssuesEvent"}}
+
+	if !f.IsEventProcessable("PushEvent") {
+		t.Error("expected PushEvent to pass an allow list that includes it")
+	}
+	if f.IsEventProcessable("ForkEvent") {
+		t.Error("expected ForkEvent to be rejected by an allow list that omits it")
+	}
+}
+
+func TestEventFilterDenyList(t *testing.T) {
+	f := EventFilter{Deny: []string{"WatchEvent"}}
+
+	if f.IsEventProcessable("WatchEvent") {
+		t.Error("expected WatchEvent to be rejected by a matching deny list")
+	}
+	if !f.IsEventProcessable("PushEvent") {
+		t.Error("expected PushEvent to pass when absent from the deny list")
+	}
+}
+
+func TestEventFilterEmptyAllowsEverything(t *testing.T) {
+	var f EventFilter
+	if !f.IsEventProcessable("AnyEvent") {
+		t.Error("expected a zero-value EventFilter to allow everything")
+	}
+}
+
+func TestRepoFilterInclude(t *testing.T) {
+	f := RepoFilter{Include: []string{"myorg/*"}}
+
+	if !f.IsRepoProcessable("myorg/widgets") {
+		t.Error("expected myorg/widgets to match the include glob")
+	}
+	if f.IsRepoProcessable("otherorg/widgets") {
+		t.Error("expected otherorg/widgets to be rejected by the include glob")
+	}
+}
+
+func TestRepoFilterExclude(t *testing.T) {
+	f := RepoFilter{Exclude: []string{"myorg/secret"}}
+
+	if f.IsRepoProcessable("myorg/secret") {
+		t.Error("expected myorg/secret to be rejected by the exclude list")
+	}
+	if !f.IsRepoProcessable("myorg/widgets") {
+		t.Error("expected myorg/widgets to pass when absent from the exclude list")
+	}
+}
+
+func TestResolveTokenPrecedence(t *testing.T) {
+	if got := resolveToken("flag-token", "config-token"); got != "flag-token" {
+		t.Errorf("expected -token flag to win, got %q", got)
+	}
+	if got := resolveToken("", "config-token"); got != "config-token" {
+		t.Errorf("expected config token to win over env/gh when flag is empty, got %q", got)
+	}
+}
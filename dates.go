@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dayLayout = "2006-01-02"
+
+// dateWindow is the inclusive [Since, Until] range of days a report covers.
+type dateWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Contains reports whether t falls on one of the days in the window.
+func (w dateWindow) Contains(t time.Time) bool {
+	day := t.Truncate(24 * time.Hour)
+	return !day.Before(w.Since) && !day.After(w.Until)
+}
+
+// resolveDateWindow builds a dateWindow from the -date, -since, and -until
+// flags. -date accepts either a single day ("2023-01-01") or a range
+// ("2023-01-01..2023-01-07"); it is mutually exclusive with -since/-until.
+func resolveDateWindow(date, since, until string) (dateWindow, error) {
+	if date != "" {
+		if since != "" || until != "" {
+			return dateWindow{}, fmt.Errorf("-date cannot be combined with -since/-until")
+		}
+
+		if start, end, ok := strings.Cut(date, ".."); ok {
+			return newDateWindow(start, end)
+		}
+		return newDateWindow(date, date)
+	}
+
+	if since == "" {
+		return dateWindow{}, fmt.Errorf("one of -date or -since is required")
+	}
+	if until == "" {
+		until = time.Now().Format(dayLayout)
+	}
+	return newDateWindow(since, until)
+}
+
+func newDateWindow(since, until string) (dateWindow, error) {
+	start, err := time.Parse(dayLayout, since)
+	if err != nil {
+		return dateWindow{}, fmt.Errorf("parsing date %q: %w", since, err)
+	}
+	end, err := time.Parse(dayLayout, until)
+	if err != nil {
+		return dateWindow{}, fmt.Errorf("parsing date %q: %w", until, err)
+	}
+	if end.Before(start) {
+		return dateWindow{}, fmt.Errorf("date range %s..%s ends before it starts", since, until)
+	}
+	return dateWindow{Since: start, Until: end}, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveDateWindowSingleDay(t *testing.T) {
+	w, err := resolveDateWindow("2023-01-01", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !w.Since.Equal(w.Until) {
+		t.Fatalf("expected single-day window, got %v..%v", w.Since, w.Until)
+	}
+}
+
+func TestResolveDateWindowRange(t *testing.T) {
+	w, err := resolveDateWindow("2023-01-01..2023-01-07", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Since.Format(dayLayout) != "2023-01-01" || w.Until.Format(dayLayout) != "2023-01-07" {
+		t.Fatalf("unexpected window: %v..%v", w.Since, w.Until)
+	}
+}
+
+func TestResolveDateWindowSinceUntil(t *testing.T) {
+	w, err := resolveDateWindow("", "2023-01-01", "2023-01-03")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Since.Format(dayLayout) != "2023-01-01" || w.Until.Format(dayLayout) != "2023-01-03" {
+		t.Fatalf("unexpected window: %v..%v", w.Since, w.Until)
+	}
+}
+
+func TestResolveDateWindowRejectsConflictingFlags(t *testing.T) {
+	if _, err := resolveDateWindow("2023-01-01", "2023-01-01", ""); err == nil {
+		t.Fatal("expected error combining -date with -since")
+	}
+}
+
+func TestResolveDateWindowRejectsInvertedRange(t *testing.T) {
+	if _, err := resolveDateWindow("2023-01-07..2023-01-01", "", ""); err == nil {
+		t.Fatal("expected error for a range that ends before it starts")
+	}
+}
+
+func TestDateWindowContains(t *testing.T) {
+	w, err := resolveDateWindow("2023-01-01..2023-01-03", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inside := time.Date(2023, 1, 2, 15, 0, 0, 0, time.UTC)
+	before := time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	if !w.Contains(inside) {
+		t.Error("expected window to contain a timestamp within range")
+	}
+	if w.Contains(before) {
+		t.Error("expected window to reject a timestamp before range")
+	}
+	if w.Contains(after) {
+		t.Error("expected window to reject a timestamp after range")
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// ActivityItem is a renderer-agnostic summary of a single GitHub activity
+// event: what happened (Label), where (Repo), and a stable deep-link (URL).
+type ActivityItem struct {
+	Type      string    `json:"type"`
+	Repo      string    `json:"repo"`
+	Label     string    `json:"label"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// User and Date are filled in by the fetch layer, which knows which
+	// login and which day of a multi-user/multi-day report this item
+	// belongs to.
+	User string `json:"user"`
+	Date string `json:"date"`
+}
+
+// buildActivityItem turns a raw GitHub event into an ActivityItem by parsing
+// its typed payload and producing a human-readable label and deep-link.
+func buildActivityItem(event *github.Event) (ActivityItem, error) {
+	repoName := event.GetRepo().GetName()
+	eventType := event.GetType()
+
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return ActivityItem{}, fmt.Errorf("parsing payload for %s: %w", eventType, err)
+	}
+
+	var label, url string
+
+	switch p := payload.(type) {
+	case *github.PullRequestEvent:
+		label = fmt.Sprintf("%s pull request #%d", strings.Title(p.GetAction()), p.GetNumber())
+		url = p.GetPullRequest().GetHTMLURL()
+	case *github.IssuesEvent:
+		label = fmt.Sprintf("%s issue #%d", strings.Title(p.GetAction()), p.GetIssue().GetNumber())
+		url = p.GetIssue().GetHTMLURL()
+	case *github.IssueCommentEvent:
+		label = fmt.Sprintf("Commented on #%d", p.GetIssue().GetNumber())
+		url = p.GetComment().GetHTMLURL()
+	case *github.PushEvent:
+		shas := make([]string, 0, len(p.Commits))
+		for _, c := range p.Commits {
+			shas = append(shas, shortSHA(c.GetSHA()))
+		}
+		label = fmt.Sprintf("Pushed %d commit(s) (%s)", len(p.Commits), strings.Join(shas, ", "))
+		url = fmt.Sprintf("https://github.com/%s/compare/%s...%s", repoName, p.GetBefore(), p.GetHead())
+	case *github.PullRequestReviewEvent:
+		label = fmt.Sprintf("Reviewed pull request #%d", p.GetPullRequest().GetNumber())
+		url = p.GetReview().GetHTMLURL()
+	case *github.PullRequestReviewCommentEvent:
+		label = fmt.Sprintf("Commented on pull request #%d", p.GetPullRequest().GetNumber())
+		url = p.GetComment().GetHTMLURL()
+	case *github.CommitCommentEvent:
+		label = "Commented on a commit"
+		url = p.GetComment().GetHTMLURL()
+	case *github.CreateEvent:
+		label = fmt.Sprintf("Created %s %s", p.GetRefType(), p.GetRef())
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	case *github.DeleteEvent:
+		label = fmt.Sprintf("Deleted %s %s", p.GetRefType(), p.GetRef())
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	case *github.ForkEvent:
+		label = "Forked repository"
+		url = p.GetForkee().GetHTMLURL()
+	case *github.ReleaseEvent:
+		label = fmt.Sprintf("Released %s", p.GetRelease().GetTagName())
+		url = p.GetRelease().GetHTMLURL()
+	case *github.WatchEvent:
+		label = "Starred repository"
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	case *github.PublicEvent:
+		label = "Made repository public"
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	case *github.MemberEvent:
+		label = fmt.Sprintf("%s collaborator %s", strings.Title(p.GetAction()), p.GetMember().GetLogin())
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	case *github.GollumEvent:
+		var pages []string
+		for _, page := range p.Pages {
+			pages = append(pages, page.GetPageName())
+		}
+		label = fmt.Sprintf("Edited wiki page(s): %s", strings.Join(pages, ", "))
+		url = fmt.Sprintf("https://github.com/%s/wiki", repoName)
+	default:
+		label = eventType
+		url = fmt.Sprintf("https://github.com/%s", repoName)
+	}
+
+	return ActivityItem{
+		Type:      eventType,
+		Repo:      repoName,
+		Label:     label,
+		URL:       url,
+		CreatedAt: event.GetCreatedAt().Time,
+	}, nil
+}
+
+// shortSHA truncates a commit SHA to 7 characters, or returns it unchanged
+// if it's already shorter (PushEventCommit.SHA can be empty).
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
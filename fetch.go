@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// fetchUserActivity pages through a user's public events (newest first,
+// capped by the API at 300 events, i.e. 3 pages of 100) and returns the ActivityItems
+// that fall inside window. It stops paging as soon as a page's oldest event
+// predates window, since later pages can only be older still.
+func fetchUserActivity(ctx context.Context, client *github.Client, login string, window dateWindow, events EventFilter, repos RepoFilter) ([]ActivityItem, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var items []ActivityItem
+	for {
+		page, resp, err := client.Activity.ListEventsPerformedByUser(ctx, login, false, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing events for %s: %w", login, err)
+		}
+
+		pageExhausted := false
+		for _, event := range page {
+			if event.CreatedAt == nil {
+				continue
+			}
+			eventTime := event.GetCreatedAt().Time
+
+			if eventTime.Before(window.Since) {
+				pageExhausted = true
+				continue
+			}
+			if !window.Contains(eventTime) {
+				continue
+			}
+			if !events.IsEventProcessable(event.GetType()) || !repos.IsRepoProcessable(event.GetRepo().GetName()) {
+				continue
+			}
+
+			item, err := buildActivityItem(event)
+			if err != nil {
+				return nil, err
+			}
+			item.User = login
+			item.Date = eventTime.Format(dayLayout)
+			items = append(items, item)
+		}
+
+		if pageExhausted || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return items, nil
+}
@@ -1,30 +1,50 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"time"
-)
+	"os"
+	"path/filepath"
+	"strings"
 
-const (
-	baseURL = "https://api.github.com"
+	"github.com/google/go-github/v58/github"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
 )
 
 var (
-	accessToken string
-	date        string
+	accessToken  string
+	configPath   string
+	date         string
+	since        string
+	until        string
+	userFlag     string
+	orgFlag      string
+	format       string
+	templatePath string
+	apiFlag      string
 )
 
-func init() {
-	flag.StringVar(&accessToken, "token", "", "GitHub personal access token")
-	flag.StringVar(&date, "date", "", "Date in YYYY-MM-DD format for which you want to retrieve GitHub activity")
+// parseFlags registers and parses the CLI flags. It lives outside init() so
+// that flag.Parse() doesn't run against `go test`'s own flags when this
+// package is compiled as a test binary.
+func parseFlags() {
+	flag.StringVar(&accessToken, "token", "", "GitHub personal access token (default: config file, then $GITHUB_TOKEN/$GH_TOKEN, then `gh auth token`)")
+	flag.StringVar(&configPath, "config", "", "Path to a JSON or YAML config file (.yaml/.yml is read as YAML, anything else as JSON)")
+	flag.StringVar(&date, "date", "", "Date (YYYY-MM-DD) or range (YYYY-MM-DD..YYYY-MM-DD) to report on")
+	flag.StringVar(&since, "since", "", "Start of the report window (YYYY-MM-DD), alternative to -date")
+	flag.StringVar(&until, "until", "", "End of the report window (YYYY-MM-DD), defaults to today when -since is set")
+	flag.StringVar(&userFlag, "user", "", "Comma-separated GitHub usernames to report on (default: config file, then -org members, then the authenticated user)")
+	flag.StringVar(&orgFlag, "org", "", "Report on all public members of this GitHub org (default: config file)")
+	flag.StringVar(&format, "format", "", "Output format: md, json, txt, or tpl (default: config file, then md)")
+	flag.StringVar(&templatePath, "template", "", "Path to a text/template file, used when -format=tpl")
+	flag.StringVar(&apiFlag, "api", "rest", "GitHub API to use: rest (Events API, up to 300 events/90 days) or graphql (ContributionsCollection, richer and not bound by the 300-event/90-day cap)")
 	flag.Parse()
 
-	if accessToken == "" || date == "" {
-		fmt.Println("Please provide a GitHub personal access token and date.")
+	if date == "" && since == "" {
+		fmt.Println("Please provide a date or -since.")
 		flag.PrintDefaults()
 		fmt.Println("Example: go run main.go -token YOUR_ACCESS_TOKEN -date 2023-01-01")
 		fmt.Println("Get your personal access token here: https://github.com/settings/tokens")
@@ -35,166 +55,112 @@ func init() {
 }
 
 func main() {
-	// Parse date string
-	parsedDate, err := time.Parse("2006-01-02", date)
+	parseFlags()
+
+	cfg, err := loadConfig(configPath)
 	if err != nil {
-		fmt.Println("Error parsing date:", err)
+		fmt.Println("Error loading config:", err)
 		return
 	}
 
-	// Generate URL for events API
-	url := fmt.Sprintf("%s/users/%s/events", baseURL, getAuthenticatedUser())
-	request, err := http.NewRequest("GET", url, nil)
+	window, err := resolveDateWindow(date, since, until)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
+		fmt.Println("Error resolving date window:", err)
 		return
 	}
 
-	// Add authentication header
-	request.Header.Set("Authorization", "token "+accessToken)
-
-	// Send request
-	client := http.Client{}
-	response, err := client.Do(request)
+	outputFormat := format
+	if outputFormat == "" {
+		outputFormat = cfg.Format
+	}
+	renderer, err := rendererFor(outputFormat, templatePath)
 	if err != nil {
-		fmt.Println("Error sending request:", err)
+		fmt.Println("Error setting up renderer:", err)
 		return
 	}
-	defer response.Body.Close()
 
-	// Read response body
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		fmt.Println("Error reading response body:", err)
-		return
+	token := resolveToken(accessToken, cfg.Token)
+
+	ctx := context.Background()
+	client := newClient(ctx, token)
+
+	org := orgFlag
+	if org == "" {
+		org = cfg.Org
 	}
 
-	// Parse JSON response
-	var events []map[string]interface{}
-	err = json.Unmarshal(body, &events)
+	users, err := resolveUsers(ctx, client, effectiveUserFlag(userFlag, cfg.Users), org)
 	if err != nil {
-		fmt.Println("Error parsing JSON:", err)
+		fmt.Println("Error resolving users:", err)
 		return
 	}
 
-	// Display events for the specified date in markdown format
-	fmt.Printf("## GitHub activity for %s\n", date)
-	fmt.Println("")
+	var graphqlClient *githubv4.Client
+	if apiFlag == "graphql" {
+		graphqlClient = newGraphQLClient(ctx, token)
+	} else if apiFlag != "rest" {
+		fmt.Printf("Unknown -api %q (want rest or graphql)\n", apiFlag)
+		return
+	}
 
-	for _, event := range events {
-		createdAt, ok := event["created_at"].(string)
-		if !ok {
-			continue
+	var items []ActivityItem
+	for _, login := range users {
+		var userItems []ActivityItem
+		var err error
+		if graphqlClient != nil {
+			userItems, err = fetchUserActivityGraphQL(ctx, graphqlClient, login, window, cfg.Events, cfg.Repos)
+		} else {
+			userItems, err = fetchUserActivity(ctx, client, login, window, cfg.Events, cfg.Repos)
 		}
-
-		eventDate, err := time.Parse(time.RFC3339, createdAt)
 		if err != nil {
-			fmt.Println("Error parsing event date:", err)
+			fmt.Fprintln(os.Stderr, "Error fetching activity for", login+":", err)
 			continue
 		}
+		items = append(items, userItems...)
+	}
 
-		if eventDate.Day() == parsedDate.Day() && eventDate.Month() == parsedDate.Month() && eventDate.Year() == parsedDate.Year() {
-			repo, ok := event["repo"].(map[string]interface{})
-			if !ok {
-				fmt.Println("Error getting repo information.")
-				continue
-			}
-
-			repoName, ok := repo["name"].(string)
-			if !ok {
-				fmt.Println("Error getting repo name.")
-				continue
-			}
-
-			eventType, ok := event["type"].(string)
-			if !ok {
-				fmt.Println("Error getting event type.")
-				continue
-			}
-
-			var eventURL string
-
-			switch eventType {
-			case "PullRequestEvent":
-				prNumber, ok := event["payload"].(map[string]interface{})["pull_request"].(map[string]interface{})["number"].(float64)
-				if !ok {
-					fmt.Println("Error getting PR number.")
-					continue
-				}
-				eventURL = fmt.Sprintf("https://github.com/%s/pull/%d", repoName, int(prNumber))
-			case "IssuesEvent":
-				issueNumber, ok := event["payload"].(map[string]interface{})["issue"].(map[string]interface{})["number"].(float64)
-				if !ok {
-					fmt.Println("Error getting issue number.")
-					continue
-				}
-				eventURL = fmt.Sprintf("https://github.com/%s/issues/%d", repoName, int(issueNumber))
-			case "IssueCommentEvent":
-				commentID, ok := event["payload"].(map[string]interface{})["comment"].(map[string]interface{})["id"].(float64)
-				if !ok {
-					fmt.Println("Error getting comment ID.")
-					continue
-				}
-
-				// Check if it's a PR or Issue comment
-				if issue, ok := event["payload"].(map[string]interface{})["issue"].(map[string]interface{}); ok {
-					issueNumber, ok := issue["number"].(float64)
-					if !ok {
-						fmt.Println("Error getting issue number.")
-						continue
-					}
-					eventURL = fmt.Sprintf("https://github.com/%s/issues/%d#issuecomment-%d", repoName, int(issueNumber), int(commentID))
-				} else if pr, ok := event["payload"].(map[string]interface{})["pull_request"].(map[string]interface{}); ok {
-					prNumber, ok := pr["number"].(float64)
-					if !ok {
-						fmt.Println("Error getting PR number.")
-						continue
-					}
-					eventURL = fmt.Sprintf("https://github.com/%s/pull/%d#issuecomment-%d", repoName, int(prNumber), int(commentID))
-				} else {
-					fmt.Println("Error getting issue or PR information for comment.")
-					continue
-				}
-			default:
-				// Default to repo URL
-				eventURL = fmt.Sprintf("https://github.com/%s", repoName)
-			}
-
-			fmt.Printf("- %s - [%s](%s)\n", eventType, repoName, eventURL)
-		}
+	if err := renderer.Render(os.Stdout, items); err != nil {
+		fmt.Println("Error rendering output:", err)
 	}
 }
 
-func getAuthenticatedUser() string {
-	url := fmt.Sprintf("%s/user", baseURL)
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return ""
+// effectiveUserFlag returns the -user flag value, falling back to the
+// config file's user list when the flag wasn't set.
+func effectiveUserFlag(userFlag string, configUsers []string) string {
+	if userFlag != "" || len(configUsers) == 0 {
+		return userFlag
 	}
+	return strings.Join(configUsers, ",")
+}
 
-	request.Header.Set("Authorization", "token "+accessToken)
-
-	client := http.Client{}
-	response, err := client.Do(request)
+// newClient builds a GitHub REST client authenticated with token, falling back
+// to an unauthenticated client (subject to GitHub's stricter rate limits) when
+// token is empty. Every request goes through a rateLimitedTransport that
+// backs off on rate-limit responses and serves conditional requests from an
+// on-disk cache.
+func newClient(ctx context.Context, token string) *github.Client {
+	cache, err := newDiskCache(cacheDir())
 	if err != nil {
-		fmt.Println("Error sending request:", err)
-		return ""
+		fmt.Fprintln(os.Stderr, "Warning: disabling on-disk cache:", err)
+		cache = diskCache{}
 	}
-	defer response.Body.Close()
+	transport := newRateLimitedTransport(http.DefaultTransport, cache)
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		fmt.Println("Error reading response body:", err)
-		return ""
+	if token == "" {
+		return github.NewClient(&http.Client{Transport: transport})
 	}
 
-	var user map[string]interface{}
-	err = json.Unmarshal(body, &user)
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return github.NewClient(oauth2.NewClient(ctx, tokenSource))
+}
+
+// cacheDir returns ~/.cache/wdidt (or the platform equivalent), or "" if it
+// can't be determined, in which case caching is disabled.
+func cacheDir() string {
+	base, err := os.UserCacheDir()
 	if err != nil {
-		fmt.Println("Error parsing JSON:", err)
 		return ""
 	}
-
-	return user["login"].(string)
+	return filepath.Join(base, "wdidt")
 }
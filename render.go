@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/template"
+)
+
+// EventRenderer turns a (possibly multi-day, multi-user) set of activity
+// items into output. Implementations decide the output format only; item
+// selection and date-window filtering happens before Render is called.
+type EventRenderer interface {
+	Render(w io.Writer, items []ActivityItem) error
+}
+
+// rendererFor resolves the -format flag to an EventRenderer, loading the
+// template file for the "tpl" format.
+func rendererFor(format, templatePath string) (EventRenderer, error) {
+	switch format {
+	case "", "md":
+		return markdownRenderer{}, nil
+	case "json":
+		return jsonRenderer{}, nil
+	case "txt":
+		return textRenderer{}, nil
+	case "tpl":
+		if templatePath == "" {
+			return nil, fmt.Errorf("-template is required when -format=tpl")
+		}
+		return newTemplateRenderer(templatePath)
+	default:
+		return nil, fmt.Errorf("unknown -format %q (want md, json, txt, or tpl)", format)
+	}
+}
+
+// dayGroup is one day's activity for one user, used by renderers that group
+// output by day and by user.
+type dayGroup struct {
+	Date  string
+	Users []userGroup
+}
+
+type userGroup struct {
+	User  string
+	Items []ActivityItem
+}
+
+// groupByDateThenUser buckets items by day, then by user within each day,
+// sorting both levels and the items within a user so renderer output is
+// stable across runs.
+func groupByDateThenUser(items []ActivityItem) []dayGroup {
+	byDate := make(map[string]map[string][]ActivityItem)
+	for _, item := range items {
+		byUser, ok := byDate[item.Date]
+		if !ok {
+			byUser = make(map[string][]ActivityItem)
+			byDate[item.Date] = byUser
+		}
+		byUser[item.User] = append(byUser[item.User], item)
+	}
+
+	dates := make([]string, 0, len(byDate))
+	for date := range byDate {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	groups := make([]dayGroup, 0, len(dates))
+	for _, date := range dates {
+		byUser := byDate[date]
+		users := make([]string, 0, len(byUser))
+		for user := range byUser {
+			users = append(users, user)
+		}
+		sort.Strings(users)
+
+		userGroups := make([]userGroup, 0, len(users))
+		for _, user := range users {
+			items := byUser[user]
+			sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+			userGroups = append(userGroups, userGroup{User: user, Items: items})
+		}
+		groups = append(groups, dayGroup{Date: date, Users: userGroups})
+	}
+	return groups
+}
+
+// markdownRenderer reproduces wdidt's original "## GitHub activity" bullet
+// list, with a sub-heading per user when a day covers more than one.
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(w io.Writer, items []ActivityItem) error {
+	for _, day := range groupByDateThenUser(items) {
+		fmt.Fprintf(w, "## GitHub activity for %s\n\n", day.Date)
+		for _, user := range day.Users {
+			if len(day.Users) > 1 {
+				fmt.Fprintf(w, "### %s\n\n", user.User)
+			}
+			for _, item := range user.Items {
+				fmt.Fprintf(w, "- %s - [%s](%s)\n", item.Label, item.Repo, item.URL)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+// textRenderer produces a plain, script-friendly line per item.
+type textRenderer struct{}
+
+func (textRenderer) Render(w io.Writer, items []ActivityItem) error {
+	for _, day := range groupByDateThenUser(items) {
+		for _, user := range day.Users {
+			for _, item := range user.Items {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", day.Date, user.User, item.Repo, item.Label, item.URL)
+			}
+		}
+	}
+	return nil
+}
+
+// jsonRenderer dumps the raw activity items, suitable for feeding into other
+// tools.
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, items []ActivityItem) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Days []dayGroup `json:"days"`
+	}{Days: groupByDateThenUser(items)})
+}
+
+// templateContext is what a -format=tpl template has available to it.
+type templateContext struct {
+	Days []dayGroup
+}
+
+// templateRenderer renders activity through a user-supplied text/template
+// file, for building standups, changelog entries, or blog posts from the
+// same data.
+type templateRenderer struct {
+	tpl *template.Template
+}
+
+func newTemplateRenderer(path string) (templateRenderer, error) {
+	tpl, err := template.ParseFiles(path)
+	if err != nil {
+		return templateRenderer{}, fmt.Errorf("loading template %s: %w", path, err)
+	}
+	return templateRenderer{tpl: tpl}, nil
+}
+
+func (r templateRenderer) Render(w io.Writer, items []ActivityItem) error {
+	return r.tpl.Execute(w, templateContext{Days: groupByDateThenUser(items)})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const maxRetries = 5
+
+// rateLimitedTransport wraps an http.RoundTripper with three things the
+// GitHub REST API rewards: (1) it sleeps ahead of a request once the prior
+// response reported an exhausted rate-limit budget, (2) it retries 403/429
+// responses with Retry-After or exponential backoff, and (3) it reissues
+// GETs as conditional requests against a diskCache, treating 304 as "reuse
+// the cached body" since conditional requests don't count against the quota.
+type rateLimitedTransport struct {
+	base  http.RoundTripper
+	cache diskCache
+
+	mu        sync.Mutex
+	remaining int
+	resetAt   time.Time
+}
+
+func newRateLimitedTransport(base http.RoundTripper, cache diskCache) *rateLimitedTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{base: base, cache: cache, remaining: -1}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.waitForRateLimit()
+
+	url := req.URL.String()
+	authorization := req.Header.Get("Authorization")
+	cacheable := req.Method == http.MethodGet
+	entry, cached := cacheEntry{}, false
+	if cacheable {
+		entry, cached = t.cache.Get(url, authorization)
+		if cached {
+			req = req.Clone(req.Context())
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		t.recordRateLimit(resp)
+
+		if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+		if attempt == maxRetries {
+			break
+		}
+
+		wait := retryDelay(resp, attempt)
+		resp.Body.Close()
+		time.Sleep(wait)
+	}
+
+	if cacheable && cached && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		resp.ContentLength = int64(len(entry.Body))
+		return resp, nil
+	}
+
+	if cacheable && resp.StatusCode == http.StatusOK {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		t.cache.Put(url, authorization, cacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+	}
+
+	return resp, nil
+}
+
+// waitForRateLimit blocks until the previously observed rate-limit window
+// resets, if the last response reported the budget as exhausted.
+func (t *rateLimitedTransport) waitForRateLimit() {
+	t.mu.Lock()
+	remaining, resetAt := t.remaining, t.resetAt
+	t.mu.Unlock()
+
+	if remaining != 0 {
+		return
+	}
+	if wait := time.Until(resetAt); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (t *rateLimitedTransport) recordRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	resetUnix, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.remaining = remaining
+	t.resetAt = time.Unix(resetUnix, 0)
+	t.mu.Unlock()
+}
+
+// retryDelay honors Retry-After on 403/429 when present, otherwise falls
+// back to exponential backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Duration(1<<attempt) * time.Second
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelayHonorsRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := retryDelay(resp, 0); got != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %v", got)
+	}
+}
+
+func TestRetryDelayFallsBackToExponentialBackoff(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	for attempt, want := range map[int]time.Duration{
+		0: 1 * time.Second,
+		1: 2 * time.Second,
+		2: 4 * time.Second,
+	} {
+		if got := retryDelay(resp, attempt); got != want {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// resolveUsers determines which user logins to fetch activity for: an
+// explicit comma-separated -user list takes precedence, then all public
+// members of -org, falling back to the authenticated user.
+func resolveUsers(ctx context.Context, client *github.Client, userFlag, orgFlag string) ([]string, error) {
+	if userFlag != "" {
+		var users []string
+		for _, u := range strings.Split(userFlag, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				users = append(users, u)
+			}
+		}
+		return users, nil
+	}
+
+	if orgFlag != "" {
+		return orgMembers(ctx, client, orgFlag)
+	}
+
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return []string{user.GetLogin()}, nil
+}
+
+// orgMembers lists the logins of all public members of org, paginating
+// through the full result set.
+func orgMembers(ctx context.Context, client *github.Client, org string) ([]string, error) {
+	opts := &github.ListMembersOptions{
+		PublicOnly:  true,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var logins []string
+	for {
+		members, resp, err := client.Organizations.ListMembers(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			logins = append(logins, m.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return logins, nil
+}